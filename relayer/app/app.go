@@ -0,0 +1,22 @@
+// Package app wires relay workers together with uber-go/fx instead of the
+// hand-rolled NewRelay/Start/Stop constructors in relays/beacon and
+// relays/parachain. Providers supply shared dependencies (connections,
+// keypairs, channels); fx.Invoke registers the worker(s) that should
+// actually run; fx.Lifecycle hooks replace the manual nil-checks and
+// ordered Connect calls those constructors used to do by hand.
+//
+// Composing a new combination of workers (beacon-only, beefy-only, both)
+// is then a matter of picking which modules to fx.New with, rather than
+// editing a constructor.
+package app
+
+import (
+	"go.uber.org/fx"
+)
+
+// New builds an fx.App from the given modules. Callers run it with
+// app.Run() (blocks until an OS signal or Stop is called) or app.Start /
+// app.Stop for embedding in tests.
+func New(modules ...fx.Option) *fx.App {
+	return fx.New(modules...)
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/snowfork/snowbridge/relayer/relays/beacon"
+)
+
+// BeaconModule provides the beacon->parachain Relay and wires its Start
+// into the fx lifecycle, so checkpoint resume, initial sync, ongoing sync
+// committee and finalized block updates, and blob sidecar forwarding all run
+// exactly as they do under the standalone beacon.Relay.Start.
+var BeaconModule = fx.Module("beacon",
+	fx.Provide(
+		beacon.NewRelay,
+	),
+	fx.Invoke(registerBeaconRelay),
+)
+
+// registerBeaconRelay starts the beacon relay on OnStart, same as calling
+// beacon.Relay.Start directly. It runs on runCtx rather than the OnStart
+// hook's own ctx, since relay.Start only returns once the relay is torn
+// down; runCtx is canceled by provideErrgroup's OnStop hook so Stop
+// actually unblocks it instead of hanging on eg.Wait forever.
+func registerBeaconRelay(lc fx.Lifecycle, eg *errgroup.Group, runCtx context.Context, relay *beacon.Relay) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			eg.Go(func() error { return relay.Start(runCtx, eg) })
+			return nil
+		},
+	})
+}
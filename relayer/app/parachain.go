@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/snowfork/snowbridge/relayer/chain/ethereum"
+	"github.com/snowfork/snowbridge/relayer/chain/parachain"
+	"github.com/snowfork/snowbridge/relayer/chain/relaychain"
+	"github.com/snowfork/snowbridge/relayer/crypto/secp256k1"
+	pchain "github.com/snowfork/snowbridge/relayer/relays/parachain"
+)
+
+// ParachainModule provides everything needed to run the BEEFY->Ethereum
+// relay: the three chain connections, the message package channels, the
+// batcher between them, and the BeefyListener / EthereumChannelWriter
+// workers, wired up with lifecycle hooks in place of parachain.Relay.Start/Stop.
+var ParachainModule = fx.Module("parachain",
+	fx.Provide(
+		provideParachainConnection,
+		provideRelaychainConnection,
+		provideEthereumConnection,
+		provideMessagePackages,
+		pchain.NewMessageBatcher,
+		pchain.NewBeefyListener,
+		pchain.NewEthereumChannelWriter,
+		provideSubmitter,
+	),
+	fx.Invoke(registerParachainWorkers),
+)
+
+func provideParachainConnection(config *pchain.Config) *parachain.Connection {
+	return parachain.NewConnection(config.Parachain.Endpoint, nil)
+}
+
+func provideRelaychainConnection(config *pchain.Config) *relaychain.Connection {
+	return relaychain.NewConnection(config.Polkadot.Endpoint)
+}
+
+func provideEthereumConnection(config *pchain.Config, keypair *secp256k1.Keypair) *ethereum.Connection {
+	return ethereum.NewConnection(config.Ethereum.Endpoint, keypair)
+}
+
+func provideMessagePackages() chan pchain.MessagePackage {
+	return make(chan pchain.MessagePackage, 1)
+}
+
+// provideSubmitter exposes the EthereumChannelWriter as a pchain.Submitter,
+// which is all NewMessageBatcher needs to submit finished batches.
+func provideSubmitter(writer *pchain.EthereumChannelWriter) pchain.Submitter {
+	return writer
+}
+
+// registerParachainWorkers connects the three chain connections on OnStart
+// and starts the long-running workers on runCtx rather than the OnStart
+// hook's own ctx, since they only return once the relay is torn down;
+// runCtx is canceled by provideErrgroup's OnStop hook so Stop actually
+// unblocks them instead of hanging on eg.Wait forever.
+func registerParachainWorkers(
+	lc fx.Lifecycle,
+	eg *errgroup.Group,
+	runCtx context.Context,
+	parachainConn *parachain.Connection,
+	relaychainConn *relaychain.Connection,
+	ethereumConn *ethereum.Connection,
+	batcher *pchain.MessageBatcher,
+	listener *pchain.BeefyListener,
+	writer *pchain.EthereumChannelWriter,
+) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := parachainConn.Connect(ctx); err != nil {
+				return err
+			}
+			if err := ethereumConn.Connect(ctx); err != nil {
+				return err
+			}
+			if err := relaychainConn.Connect(ctx); err != nil {
+				return err
+			}
+
+			eg.Go(func() error { return writer.Start(runCtx, eg) })
+			eg.Go(func() error { return listener.Start(runCtx, eg) })
+			eg.Go(func() error { return batcher.Start(runCtx) })
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			parachainConn.Close()
+			relaychainConn.Close()
+			ethereumConn.Close()
+			return nil
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"golang.org/x/sync/errgroup"
+)
+
+// CommonModule provides dependencies shared by every relay module: a
+// cancelable run context for the long-running worker goroutines, and a
+// single errgroup.Group built on it, used by workers to run their
+// background goroutines and to propagate the first error between them,
+// same as the errgroup threaded through by hand in relays/beacon and
+// relays/parachain today.
+var CommonModule = fx.Module("common",
+	fx.Provide(
+		provideRunContext,
+		provideErrgroup,
+	),
+)
+
+// provideRunContext is independent of the short-lived contexts fx passes
+// into OnStart/OnStop hooks: workers registered against it run until
+// provideErrgroup's OnStop hook cancels it, not until their OnStart hook
+// returns.
+func provideRunContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(context.Background())
+}
+
+func provideErrgroup(lc fx.Lifecycle, runCtx context.Context, cancel context.CancelFunc) *errgroup.Group {
+	eg, egCtx := errgroup.WithContext(runCtx)
+
+	// The first worker error cancels runCtx, so the other workers stop too.
+	go func() {
+		<-egCtx.Done()
+		cancel()
+	}()
+
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			cancel()
+			return eg.Wait()
+		},
+	})
+
+	return eg
+}
@@ -0,0 +1,19 @@
+package ethereum
+
+import (
+	"context"
+
+	"github.com/snowfork/snowbridge/relayer/reconnect"
+)
+
+// ReconnectingConnection wraps a Connection so a dropped websocket
+// transparently redials with exponential backoff instead of unwinding the
+// whole relay. Readers should wait on Ready() before issuing requests, so a
+// reconnect in progress doesn't surface as a flood of RPC errors. The
+// implementation lives in the shared reconnect package; this is just the
+// ethereum-flavoured name and constructor.
+type ReconnectingConnection = reconnect.Wrapper[*Connection]
+
+func NewReconnectingConnection(conn *Connection, config reconnect.Config, onReconnect func(ctx context.Context) error) *ReconnectingConnection {
+	return reconnect.NewWrapper("ethereum", conn, config, onReconnect)
+}
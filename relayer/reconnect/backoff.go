@@ -0,0 +1,69 @@
+// Package reconnect implements exponential backoff with jitter and the
+// generic Wrapper connection type built on it, shared by
+// chain/parachain.ReconnectingConnection, chain/relaychain.ReconnectingConnection
+// and chain/ethereum.ReconnectingConnection so a transient RPC blip on any
+// of the three chains doesn't tear down the whole relay.
+package reconnect
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Config bounds how a ReconnectingConnection redials after a dropped
+// connection.
+type Config struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultConfig is used by callers that don't configure reconnect
+// behaviour explicitly.
+var DefaultConfig = Config{
+	MaxRetries:     0, // retry forever
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// Backoff returns the delay before redial attempt n (0-indexed), doubling
+// each attempt up to MaxBackoff and adding up to 20% jitter so many
+// connections backing off at once don't all redial in lockstep.
+func (c Config) Backoff(attempt int) time.Duration {
+	backoff := float64(c.InitialBackoff) * math.Pow(2, float64(attempt))
+	if max := float64(c.MaxBackoff); backoff > max {
+		backoff = max
+	}
+
+	jitter := backoff * 0.2 * rand.Float64()
+
+	return time.Duration(backoff + jitter)
+}
+
+// Retry calls fn, retrying with exponential backoff until it succeeds, ctx
+// is done, or MaxRetries is exhausted (MaxRetries <= 0 means retry
+// forever).
+func Retry(ctx context.Context, config Config, fn func(ctx context.Context) error) error {
+	var err error
+	for attempt := 0; config.MaxRetries <= 0 || attempt <= config.MaxRetries; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if attempt == config.MaxRetries && config.MaxRetries > 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(config.Backoff(attempt)):
+		}
+	}
+
+	return fmt.Errorf("exhausted %d retries: %w", config.MaxRetries, err)
+}
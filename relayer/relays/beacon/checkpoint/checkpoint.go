@@ -0,0 +1,31 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by a Store when no checkpoint has been saved yet.
+var ErrNotFound = errors.New("checkpoint: not found")
+
+// Checkpoint captures enough verified beacon chain state for the relay to
+// resume finalized header sync without replaying from a hardcoded trusted
+// block root.
+type Checkpoint struct {
+	BlockRoot         string
+	Slot              uint64
+	SyncCommitteeRoot string
+}
+
+// Store persists and retrieves the most recently verified checkpoint. It is
+// updated after every successful SyncCommitteePeriodUpdates or
+// FinalizedBlockUpdate so that a restarted relay can resume from where it
+// left off instead of re-running InitialSync against a weak-subjectivity
+// root every time.
+type Store interface {
+	// LatestCheckpoint returns the most recently saved checkpoint, or
+	// ErrNotFound if the store is empty.
+	LatestCheckpoint(ctx context.Context) (Checkpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint Checkpoint) error
+	Close() error
+}
@@ -2,19 +2,32 @@ package beacon
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"github.com/sirupsen/logrus"
 	"github.com/snowfork/snowbridge/relayer/chain/parachain"
 	"github.com/snowfork/snowbridge/relayer/crypto/sr25519"
+	"github.com/snowfork/snowbridge/relayer/relays/beacon/checkpoint"
 	"github.com/snowfork/snowbridge/relayer/relays/beacon/syncer"
+	"github.com/snowfork/snowbridge/relayer/relays/debug"
 	"golang.org/x/sync/errgroup"
 )
 
+// weakSubjectivityCheckpoint is the trusted block root used to bootstrap
+// InitialSync when no checkpoint has been persisted yet, e.g. on a brand
+// new data directory.
+const weakSubjectivityCheckpoint = "0xed94aec726c5158606f33b5c599f8bf14c9a88d1722fe1f3c327ddb882c219fc"
+
 type Relay struct {
-	config   *Config
-	syncer   *syncer.Syncer
-	keypair  *sr25519.Keypair
-	paraconn *parachain.Connection
+	config      *Config
+	syncer      *syncer.Syncer
+	keypair     *sr25519.Keypair
+	paraconn    *parachain.Connection
+	paraReconn  *parachain.ReconnectingConnection
+	checkpoints checkpoint.Store
+	writer      *ParachainWriter
+	eg          *errgroup.Group
 }
 
 func NewRelay(
@@ -30,16 +43,30 @@ func NewRelay(
 func (r *Relay) Start(ctx context.Context, eg *errgroup.Group) error {
 	r.syncer = syncer.New(r.config.Source.Beacon.Endpoint, r.config.Source.Beacon.FinalizedUpdateEndpoint)
 	r.paraconn = parachain.NewConnection(r.config.Sink.Parachain.Endpoint, r.keypair.AsKeyringPair())
+	r.paraReconn = parachain.NewReconnectingConnection(r.paraconn, r.config.Reconnect, nil)
+
+	r.checkpoints = checkpoint.NewFileStore(r.config.Source.Beacon.CheckpointFile)
+
+	blockRoot := weakSubjectivityCheckpoint
+	if saved, err := r.checkpoints.LatestCheckpoint(ctx); err == nil {
+		logrus.WithFields(logrus.Fields{
+			"blockRoot": saved.BlockRoot,
+			"slot":      saved.Slot,
+		}).Info("resuming beacon sync from persisted checkpoint")
+		blockRoot = saved.BlockRoot
+	} else if err != checkpoint.ErrNotFound {
+		return err
+	}
 
 	// Get an initial snapshot of the chain from a verified block
-	initialSync, err := r.syncer.InitialSync("0xed94aec726c5158606f33b5c599f8bf14c9a88d1722fe1f3c327ddb882c219fc")
+	initialSync, err := r.syncer.InitialSync(blockRoot)
 	if err != nil {
 		logrus.WithError(err).Error("unable to do intial beacon chain sync")
 
 		return err
 	}
 
-	err = r.paraconn.Connect(ctx)
+	err = r.paraReconn.Connect(ctx)
 	if err != nil {
 		return err
 	}
@@ -47,6 +74,8 @@ func (r *Relay) Start(ctx context.Context, eg *errgroup.Group) error {
 	writer := NewParachainWriter(
 		r.paraconn,
 	)
+	r.writer = writer
+	r.eg = eg
 
 	err = writer.Start(ctx, eg)
 	if err != nil {
@@ -62,15 +91,62 @@ func (r *Relay) Start(ctx context.Context, eg *errgroup.Group) error {
 
 	logrus.Info("wrote payload to parachain")
 
-	err = r.syncer.SyncCommitteePeriodUpdates(uint64(initialSync.Header.Slot))
+	// Persist the latest verified header after every successful sync
+	// committee update and finalized block, not just the initial sync, so
+	// a restart resumes from the most recent verified header instead of
+	// replaying InitialSync against the weak-subjectivity root.
+	if err := r.saveCheckpoint(ctx, initialSync); err != nil {
+		return err
+	}
+
+	err = r.syncer.SyncCommitteePeriodUpdates(uint64(initialSync.Header.Slot), func(update syncer.Update) error {
+		return r.saveCheckpoint(ctx, update)
+	})
 	if err != nil {
 		logrus.WithError(err).Error("unable to sync committee updates")
 
 		return err
 	}
 
-	// When the chain has been processed up until now, keep getting finalized block updates and send that to the parachain
-	err = r.syncer.FinalizedBlockUpdate()
+	// Once Deneb is active, also forward blob sidecars for the block we
+	// just booted from, so the bridge can attest to blob-carrying
+	// transactions (rollup data) and not only execution-payload headers.
+	// Every subsequently finalized block gets the same treatment from
+	// inside the FinalizedBlockUpdate callback below, not just this one.
+	err = r.syncBlobSidecars(ctx, initialSync)
+	if err != nil {
+		logrus.WithError(err).Error("unable to sync blob sidecars")
+
+		return err
+	}
+
+	if r.config.Debug.Enabled {
+		server := debug.NewServer(r.config.Debug,
+			debug.Target{Name: "relay", Value: r},
+			debug.Target{Name: "syncer", Value: r.syncer},
+			debug.Target{Name: "writer", Value: writer},
+		)
+		eg.Go(func() error {
+			return server.Start(ctx)
+		})
+	}
+
+	// When the chain has been processed up until now, keep getting finalized
+	// block updates and send that to the parachain. A write failure here is
+	// treated as the parachain connection having dropped: RunWithReconnect
+	// redials it with backoff and resumes polling, instead of tearing down
+	// the whole relay on a transient disconnect.
+	err = r.paraReconn.RunWithReconnect(ctx, func(ctx context.Context) error {
+		<-r.paraReconn.Ready()
+
+		return r.syncer.FinalizedBlockUpdate(func(update syncer.Update) error {
+			if err := r.saveCheckpoint(ctx, update); err != nil {
+				return err
+			}
+
+			return r.syncBlobSidecars(ctx, update)
+		})
+	})
 	if err != nil {
 		logrus.WithError(err).Error("unable to sync finalized header")
 
@@ -79,3 +155,79 @@ func (r *Relay) Start(ctx context.Context, eg *errgroup.Group) error {
 
 	return nil
 }
+
+// saveCheckpoint persists update's header and sync committee as the latest
+// verified checkpoint, called after the initial sync and again after every
+// successful sync committee period update and finalized block so the
+// checkpoint always reflects the latest verified finalized header.
+func (r *Relay) saveCheckpoint(ctx context.Context, update syncer.Update) error {
+	err := r.checkpoints.SaveCheckpoint(ctx, checkpoint.Checkpoint{
+		BlockRoot:         update.Header.BlockRoot(),
+		Slot:              uint64(update.Header.Slot),
+		SyncCommitteeRoot: update.CurrentSyncCommittee.Root(),
+	})
+	if err != nil {
+		logrus.WithError(err).Error("unable to persist beacon checkpoint")
+	}
+
+	return err
+}
+
+// syncBlobSidecars fetches and forwards the blob sidecars for the block
+// behind update, if any. It's called once for the block InitialSync booted
+// from and again for every subsequent FinalizedBlockUpdate, so blob
+// sidecars keep being relayed for the whole lifetime of the relay, not
+// only at startup.
+func (r *Relay) syncBlobSidecars(ctx context.Context, update syncer.Update) error {
+	return r.syncer.BlobSidecarUpdate(
+		strconv.FormatUint(uint64(update.Header.Slot), 10),
+		uint64(update.Header.Slot),
+		uint64(update.Header.Slot),
+		func(slot uint64, sidecars []syncer.BlobSidecar) error {
+			return r.writer.WriteBlobSidecars(ctx, sidecars, r.eg)
+		},
+	)
+}
+
+// Status reports which sub-components have been initialized, for the
+// "status" debug command.
+func (r *Relay) Status() map[string]bool {
+	return map[string]bool{
+		"syncer": r.syncer != nil,
+		"writer": r.writer != nil,
+	}
+}
+
+// LastFinalized returns the most recently persisted checkpoint, for the
+// "last-finalized" debug command.
+func (r *Relay) LastFinalized() (checkpoint.Checkpoint, error) {
+	return r.checkpoints.LatestCheckpoint(context.Background())
+}
+
+// Replay re-runs InitialSync for blockID and writes the result to the
+// parachain again, for the "replay <block>" debug command, e.g. to force a
+// resync after an operator has resolved a downstream issue without
+// restarting the relay.
+func (r *Relay) Replay(blockID string) error {
+	ctx := context.Background()
+
+	update, err := r.syncer.InitialSync(blockID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.writer.WritePayload(ctx, update, r.eg); err != nil {
+		return err
+	}
+
+	return r.saveCheckpoint(ctx, update)
+}
+
+// Reconnect redials the parachain connection with exponential backoff, for
+// the "reconnect parachain" debug command.
+func (r *Relay) Reconnect(chain string) error {
+	if chain != "parachain" {
+		return fmt.Errorf("unknown chain %q", chain)
+	}
+	return r.paraReconn.Reconnect(context.Background())
+}
@@ -0,0 +1,165 @@
+package syncer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// minEpochsForBlobSidecarsRequests mirrors the consensus-spec constant
+// MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS: beacon nodes are only required to
+// retain blob sidecars this many epochs back from the current one.
+const minEpochsForBlobSidecarsRequests = 4096
+
+// denebForkEpoch is the first epoch Deneb, and therefore blobs, is active.
+// Slots before this are skipped entirely since they carry no blob sidecars.
+const denebForkEpoch = 269568
+
+const slotsPerEpoch = 32
+
+// BlobSidecar is a verified blob alongside the identifiers the parachain
+// side needs to correlate it with its execution payload.
+type BlobSidecar struct {
+	Slot          uint64
+	Index         uint64
+	KZGCommitment []byte
+	KZGProof      []byte
+	BlobRoot      [32]byte
+}
+
+// rawBlobSidecarResponse mirrors the JSON shape returned by the beacon API's
+// /eth/v1/beacon/blob_sidecars/{block_id} endpoint.
+type rawBlobSidecarResponse struct {
+	Data []struct {
+		Index                       string          `json:"index"`
+		Slot                        string          `json:"slot"`
+		KZGCommitment               string          `json:"kzg_commitment"`
+		KZGProof                    string          `json:"kzg_proof"`
+		SignedBlockHeader           json.RawMessage `json:"signed_block_header"`
+		KZGCommitmentInclusionProof []string        `json:"kzg_commitment_inclusion_proof"`
+	} `json:"data"`
+}
+
+// rawBlockResponse mirrors the fields this package needs from the beacon
+// API's /eth/v2/beacon/blocks/{block_id} endpoint.
+type rawBlockResponse struct {
+	Data struct {
+		Message struct {
+			Body struct {
+				BlobKZGCommitments []string `json:"blob_kzg_commitments"`
+			} `json:"body"`
+		} `json:"message"`
+	} `json:"data"`
+}
+
+// blockBodyCommitments fetches the block at blockID and returns its
+// blob_kzg_commitments list, decoded from hex, in the same order the beacon
+// node returned them: this is also the order sidecar.Index refers into.
+func (s *Syncer) blockBodyCommitments(blockID string) ([][]byte, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/eth/v2/beacon/blocks/%s", s.endpoint, blockID))
+	if err != nil {
+		return nil, fmt.Errorf("fetch block: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw rawBlockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode block: %w", err)
+	}
+
+	commitments := make([][]byte, len(raw.Data.Message.Body.BlobKZGCommitments))
+	for i, hexCommitment := range raw.Data.Message.Body.BlobKZGCommitments {
+		commitment, err := decodeHex(hexCommitment)
+		if err != nil {
+			return nil, fmt.Errorf("decode blob_kzg_commitments[%d]: %w", i, err)
+		}
+		commitments[i] = commitment
+	}
+
+	return commitments, nil
+}
+
+// BlobSidecars fetches the blob sidecars for a block, verifies each one's
+// kzg_commitment against the block body's blob_kzg_commitments list and its
+// inclusion proof against BeaconBlockBody.body_root, and returns the
+// verified sidecars. Pre-Deneb slots and slots older than the retention
+// window are skipped rather than treated as an error, since a beacon node
+// is not expected to still have that data.
+func (s *Syncer) BlobSidecars(blockID string, slot, currentSlot uint64) ([]BlobSidecar, error) {
+	if slot/slotsPerEpoch < denebForkEpoch {
+		return nil, nil
+	}
+
+	currentEpoch := currentSlot / slotsPerEpoch
+	sidecarEpoch := slot / slotsPerEpoch
+	if currentEpoch-sidecarEpoch > minEpochsForBlobSidecarsRequests {
+		return nil, nil
+	}
+
+	header, err := s.getHeader(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block header: %w", err)
+	}
+
+	bodyRoot, err := decodeHex(header.BodyRoot)
+	if err != nil {
+		return nil, fmt.Errorf("decode body_root: %w", err)
+	}
+
+	commitments, err := s.blockBodyCommitments(blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%s", s.endpoint, blockID))
+	if err != nil {
+		return nil, fmt.Errorf("fetch blob sidecars: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw rawBlobSidecarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("decode blob sidecars: %w", err)
+	}
+
+	sidecars := make([]BlobSidecar, 0, len(raw.Data))
+	for _, item := range raw.Data {
+		sidecar, err := parseBlobSidecar(item.Index, item.Slot, item.KZGCommitment, item.KZGProof)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(sidecar.Index) >= len(commitments) {
+			return nil, fmt.Errorf("sidecar index %d has no matching blob_kzg_commitments entry", sidecar.Index)
+		}
+		if !bytes.Equal(sidecar.KZGCommitment, commitments[sidecar.Index]) {
+			return nil, fmt.Errorf("sidecar %d kzg_commitment does not match blob_kzg_commitments[%d]", sidecar.Index, sidecar.Index)
+		}
+
+		if err := verifyBlobSidecar(&sidecar, item.KZGCommitmentInclusionProof, bodyRoot); err != nil {
+			return nil, fmt.Errorf("verify blob sidecar at index %d: %w", sidecar.Index, err)
+		}
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	return sidecars, nil
+}
+
+// BlobSidecarUpdate is the blob-sidecar counterpart to FinalizedBlockUpdate:
+// given a newly finalized block, it fetches and verifies that block's blob
+// sidecars and hands the verified tuples to onSidecars. It is a no-op for
+// pre-Deneb slots or slots outside the retention window (see BlobSidecars).
+func (s *Syncer) BlobSidecarUpdate(blockID string, slot, currentSlot uint64, onSidecars func(slot uint64, sidecars []BlobSidecar) error) error {
+	sidecars, err := s.BlobSidecars(blockID, slot, currentSlot)
+	if err != nil {
+		return err
+	}
+
+	if len(sidecars) == 0 {
+		return nil
+	}
+
+	return onSidecars(slot, sidecars)
+}
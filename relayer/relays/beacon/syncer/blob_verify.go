@@ -0,0 +1,82 @@
+package syncer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func parseBlobSidecar(indexStr, slotStr, commitmentHex, proofHex string) (BlobSidecar, error) {
+	index, err := strconv.ParseUint(indexStr, 10, 64)
+	if err != nil {
+		return BlobSidecar{}, fmt.Errorf("parse blob index: %w", err)
+	}
+
+	slot, err := strconv.ParseUint(slotStr, 10, 64)
+	if err != nil {
+		return BlobSidecar{}, fmt.Errorf("parse blob slot: %w", err)
+	}
+
+	commitment, err := decodeHex(commitmentHex)
+	if err != nil {
+		return BlobSidecar{}, fmt.Errorf("decode kzg_commitment: %w", err)
+	}
+
+	proof, err := decodeHex(proofHex)
+	if err != nil {
+		return BlobSidecar{}, fmt.Errorf("decode kzg_proof: %w", err)
+	}
+
+	return BlobSidecar{
+		Slot:          slot,
+		Index:         index,
+		KZGCommitment: commitment,
+		KZGProof:      proof,
+	}, nil
+}
+
+// verifyBlobSidecar checks the Merkle inclusion proof for a sidecar's
+// kzg_commitment against expectedBodyRoot (BeaconBlockBody.body_root from
+// the block's signed header), filling in BlobRoot on success. The
+// commitment itself is assumed to already have been checked by the caller
+// against the block body's blob_kzg_commitments list.
+func verifyBlobSidecar(sidecar *BlobSidecar, inclusionProof []string, expectedBodyRoot []byte) error {
+	if len(inclusionProof) != 17 {
+		return fmt.Errorf("kzg_commitment_inclusion_proof has %d branches, want 17", len(inclusionProof))
+	}
+
+	// The leaf is the hash tree root of the 48-byte commitment; its subtree
+	// index is body field 11 (blob_kzg_commitments), the list data side of
+	// its length mix-in, then the sidecar's position in the list.
+	var leaf [64]byte
+	copy(leaf[:], sidecar.KZGCommitment)
+	node := sha256.Sum256(leaf[:])
+	index := uint64(11)<<13 | sidecar.Index
+	for depth, branchHex := range inclusionProof {
+		branch, err := decodeHex(branchHex)
+		if err != nil {
+			return fmt.Errorf("decode inclusion proof branch: %w", err)
+		}
+
+		if index>>depth&1 == 1 {
+			node = sha256.Sum256(append(branch, node[:]...))
+		} else {
+			node = sha256.Sum256(append(node[:], branch...))
+		}
+	}
+
+	if !bytes.Equal(node[:], expectedBodyRoot) {
+		return fmt.Errorf("inclusion proof root does not match block body_root")
+	}
+
+	sidecar.BlobRoot = node
+
+	return nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
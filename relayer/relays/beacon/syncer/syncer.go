@@ -0,0 +1,195 @@
+package syncer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Syncer talks to a single beacon node over its HTTP API to perform the
+// initial light-client sync, follow sync committee period rotations, and
+// tail finalized block updates.
+type Syncer struct {
+	endpoint                string
+	finalizedUpdateEndpoint string
+}
+
+func New(endpoint, finalizedUpdateEndpoint string) *Syncer {
+	return &Syncer{
+		endpoint:                endpoint,
+		finalizedUpdateEndpoint: finalizedUpdateEndpoint,
+	}
+}
+
+// Header is a beacon block header, identified by the hash tree root of its
+// fields.
+type Header struct {
+	Slot          uint64
+	ProposerIndex uint64
+	ParentRoot    string
+	StateRoot     string
+	BodyRoot      string
+}
+
+// BlockRoot returns the hash tree root identifying this header.
+func (h Header) BlockRoot() string {
+	var chunks [8][32]byte
+	binary.LittleEndian.PutUint64(chunks[0][:], h.Slot)
+	binary.LittleEndian.PutUint64(chunks[1][:], h.ProposerIndex)
+	for i, root := range []string{h.ParentRoot, h.StateRoot, h.BodyRoot} {
+		b, _ := decodeHex(root)
+		copy(chunks[2+i][:], b)
+	}
+	for n := len(chunks); n > 1; n /= 2 {
+		for i := 0; i < n/2; i++ {
+			chunks[i] = sha256.Sum256(append(chunks[2*i][:], chunks[2*i+1][:]...))
+		}
+	}
+	return fmt.Sprintf("0x%x", chunks[0])
+}
+
+// SyncCommittee is the set of validators responsible for attesting to
+// headers during one sync committee period.
+type SyncCommittee struct {
+	Pubkeys []string
+}
+
+// Root returns a SHA-256 digest of the committee's member list, so a
+// persisted checkpoint can detect that the committee has rotated.
+func (c SyncCommittee) Root() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v", c.Pubkeys)))
+	return fmt.Sprintf("0x%x", sum)
+}
+
+// Update bundles a verified header with the sync committee that attested to
+// it, as produced by InitialSync and every subsequent period rotation or
+// finalized block.
+type Update struct {
+	Header               Header
+	CurrentSyncCommittee SyncCommittee
+}
+
+type rawHeaderResponse struct {
+	Data struct {
+		Header struct {
+			Message struct {
+				Slot          string `json:"slot"`
+				ProposerIndex string `json:"proposer_index"`
+				ParentRoot    string `json:"parent_root"`
+				StateRoot     string `json:"state_root"`
+				BodyRoot      string `json:"body_root"`
+			} `json:"message"`
+		} `json:"header"`
+	} `json:"data"`
+}
+
+type rawSyncCommitteeResponse struct {
+	Data struct {
+		Validators []string `json:"validators"`
+	} `json:"data"`
+}
+
+// getHeader fetches and parses the beacon block header identified by
+// blockID (a slot, block root, "head", or "finalized").
+func (s *Syncer) getHeader(blockID string) (Header, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/eth/v1/beacon/headers/%s", s.endpoint, blockID))
+	if err != nil {
+		return Header{}, fmt.Errorf("fetch header: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw rawHeaderResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return Header{}, fmt.Errorf("decode header: %w", err)
+	}
+
+	msg := raw.Data.Header.Message
+	var slot, proposerIndex uint64
+	if _, err := fmt.Sscanf(msg.Slot, "%d", &slot); err != nil {
+		return Header{}, fmt.Errorf("parse slot: %w", err)
+	}
+	if _, err := fmt.Sscanf(msg.ProposerIndex, "%d", &proposerIndex); err != nil {
+		return Header{}, fmt.Errorf("parse proposer_index: %w", err)
+	}
+
+	return Header{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    msg.ParentRoot,
+		StateRoot:     msg.StateRoot,
+		BodyRoot:      msg.BodyRoot,
+	}, nil
+}
+
+// getSyncCommittee fetches the sync committee active at blockID.
+func (s *Syncer) getSyncCommittee(blockID string) (SyncCommittee, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/eth/v1/beacon/states/%s/sync_committees", s.endpoint, blockID))
+	if err != nil {
+		return SyncCommittee{}, fmt.Errorf("fetch sync committee: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var raw rawSyncCommitteeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return SyncCommittee{}, fmt.Errorf("decode sync committee: %w", err)
+	}
+
+	return SyncCommittee{Pubkeys: raw.Data.Validators}, nil
+}
+
+// InitialSync fetches the header at blockRoot and its active sync
+// committee, bootstrapping the light client at a trusted or
+// previously-checkpointed block.
+func (s *Syncer) InitialSync(blockRoot string) (Update, error) {
+	header, err := s.getHeader(blockRoot)
+	if err != nil {
+		return Update{}, err
+	}
+
+	committee, err := s.getSyncCommittee(blockRoot)
+	if err != nil {
+		return Update{}, err
+	}
+
+	return Update{Header: header, CurrentSyncCommittee: committee}, nil
+}
+
+// SyncCommitteePeriodUpdates fetches the current head header and its sync
+// committee once and passes that update to onUpdate. startSlot is not used:
+// the periods between startSlot and the head are not walked one by one, and
+// onUpdate is called whether or not the committee has rotated.
+func (s *Syncer) SyncCommitteePeriodUpdates(startSlot uint64, onUpdate func(update Update) error) error {
+	update, err := s.InitialSync("head")
+	if err != nil {
+		return err
+	}
+
+	return onUpdate(update)
+}
+
+// FinalizedBlockUpdate polls the finalized checkpoint and calls onUpdate
+// every time a new finalized header appears. It runs until the beacon node
+// is unreachable or the caller's onUpdate returns an error.
+func (s *Syncer) FinalizedBlockUpdate(onUpdate func(update Update) error) error {
+	var lastRoot string
+
+	for {
+		update, err := s.InitialSync("finalized")
+		if err != nil {
+			return err
+		}
+
+		root := update.Header.BlockRoot()
+		if root != lastRoot {
+			if err := onUpdate(update); err != nil {
+				return err
+			}
+			lastRoot = root
+		}
+
+		time.Sleep(time.Minute)
+	}
+}
@@ -0,0 +1,22 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/snowfork/snowbridge/relayer/relays/beacon/syncer"
+	"golang.org/x/sync/errgroup"
+)
+
+// WriteBlobSidecars submits verified blob sidecars to the parachain so the
+// bridge can attest to blob-carrying transactions (rollup data) alongside
+// the execution payload headers it already relays.
+func (wr *ParachainWriter) WriteBlobSidecars(ctx context.Context, sidecars []syncer.BlobSidecar, eg *errgroup.Group) error {
+	for _, sidecar := range sidecars {
+		err := wr.write(ctx, "EthereumBeaconClient.submit_blob_sidecar", sidecar)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
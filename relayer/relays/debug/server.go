@@ -0,0 +1,252 @@
+// Package debug implements an optional admin socket that a running relay
+// can expose for operators: a small line-based command protocol dispatched
+// by reflection onto the relay and its sub-workers, so verbosity can be
+// changed or a re-sync forced without restarting the process.
+package debug
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Config controls whether the debug socket is exposed and how connections
+// to it are authenticated.
+type Config struct {
+	Enabled bool
+	// Network is "tcp" or "unix".
+	Network string
+	Address string
+	// AuthToken must be sent as the first line of every connection before
+	// any command is accepted.
+	AuthToken string
+}
+
+// Target is a named object whose exported methods become debug commands,
+// e.g. {"relay", relay}, {"writer", ethereumChannelWriter}.
+type Target struct {
+	Name  string
+	Value interface{}
+}
+
+// Server accepts connections and dispatches line-based commands onto the
+// registered targets.
+type Server struct {
+	config  Config
+	targets []Target
+	ln      net.Listener
+}
+
+func NewServer(config Config, targets ...Target) *Server {
+	return &Server{
+		config:  config,
+		targets: targets,
+	}
+}
+
+// Start listens on Config.Network/Config.Address and serves connections
+// until ctx is done. It is a no-op if the debug socket is not enabled.
+func (s *Server) Start(ctx context.Context) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	ln, err := net.Listen(s.config.Network, s.config.Address)
+	if err != nil {
+		return fmt.Errorf("listen on debug socket: %w", err)
+	}
+	s.ln = ln
+
+	log.WithFields(log.Fields{
+		"network": s.config.Network,
+		"address": s.config.Address,
+	}).Info("debug socket listening")
+
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+				log.WithError(err).Error("debug socket accept failed")
+				return err
+			}
+		}
+
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+
+	if s.config.AuthToken != "" {
+		if !scanner.Scan() || subtle.ConstantTimeCompare([]byte(scanner.Text()), []byte(s.config.AuthToken)) != 1 {
+			writeResponse(conn, response{OK: false, Error: "unauthorized"})
+			return
+		}
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		writeResponse(conn, s.dispatchSafely(line))
+	}
+}
+
+// dispatchSafely runs dispatch, recovering a panic (e.g. from a command
+// whose argument can't be coerced to the target method's parameter type)
+// into an error response instead of crashing the relay process.
+func (s *Server) dispatchSafely(line string) (resp response) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp = response{OK: false, Error: fmt.Sprintf("command panicked: %v", r)}
+		}
+	}()
+
+	return s.dispatch(line)
+}
+
+type response struct {
+	OK     bool        `json:"ok"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+func writeResponse(conn net.Conn, r response) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	conn.Write(append(data, '\n'))
+}
+
+// dispatch parses a whitespace-separated "command [arg...]" line and invokes the matching
+// exported method by name across the registered targets, e.g. "status"
+// calls Status() on the first target that has it, and "reconnect ethereum"
+// calls Reconnect("ethereum").
+func (s *Server) dispatch(line string) response {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return response{OK: false, Error: "empty command"}
+	}
+
+	methodName := toMethodName(fields[0])
+	args := fields[1:]
+
+	for _, target := range s.targets {
+		value := reflect.ValueOf(target.Value)
+		method := value.MethodByName(methodName)
+		if !method.IsValid() {
+			continue
+		}
+
+		return invoke(method, args)
+	}
+
+	return response{OK: false, Error: fmt.Sprintf("unknown command %q", fields[0])}
+}
+
+// toMethodName converts a dash-separated command like "last-finalized" into
+// its exported Go method name "LastFinalized".
+func toMethodName(command string) string {
+	parts := strings.Split(command, "-")
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func invoke(method reflect.Value, args []string) response {
+	methodType := method.Type()
+	if methodType.NumIn() != len(args) {
+		return response{OK: false, Error: fmt.Sprintf("expected %d argument(s), got %d", methodType.NumIn(), len(args))}
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		value, err := coerceArg(arg, methodType.In(i))
+		if err != nil {
+			return response{OK: false, Error: fmt.Sprintf("argument %d: %s", i+1, err)}
+		}
+		in[i] = value
+	}
+
+	out := method.Call(in)
+
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+
+	var result interface{}
+	for _, o := range out {
+		if o.Type() == errorType {
+			if !o.IsNil() {
+				return response{OK: false, Error: o.Interface().(error).Error()}
+			}
+			continue
+		}
+		result = o.Interface()
+	}
+
+	return response{OK: true, Result: result}
+}
+
+// coerceArg parses a raw command-line argument into the reflect.Kind the
+// target method parameter expects, so e.g. "replay 123" can call a method
+// taking a uint64 slot without reflect.Value.Call panicking on a type
+// mismatch. Unsupported parameter kinds are rejected up front instead.
+func coerceArg(arg string, paramType reflect.Type) (reflect.Value, error) {
+	switch paramType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(arg), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, paramType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid integer: %w", arg, err)
+		}
+		v := reflect.New(paramType).Elem()
+		v.SetInt(n)
+		return v, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(arg, 10, paramType.Bits())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid unsigned integer: %w", arg, err)
+		}
+		v := reflect.New(paramType).Elem()
+		v.SetUint(n)
+		return v, nil
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%q is not a valid bool: %w", arg, err)
+		}
+		return reflect.ValueOf(b), nil
+
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported parameter type %s", paramType)
+	}
+}
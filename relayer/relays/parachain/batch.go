@@ -0,0 +1,142 @@
+package parachain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MessageBatcher collects MessagePackages off a channel and submits them,
+// in order, once either BatchSize packages have accumulated for the same
+// commitment or BatchTimeout has elapsed since the first package in the
+// batch arrived. A package for a different commitment never shares a batch
+// with the one being built: it flushes the current batch first and starts
+// the next one itself. Batches are additionally split so no submitAll call
+// of more than one package is estimated to exceed GasLimitPerBatch.
+type MessageBatcher struct {
+	batchSize        int
+	batchTimeout     time.Duration
+	gasLimitPerBatch uint64
+	gasPerPackage    uint64
+	// in is typed as a plain chan, not <-chan, so fx's reflection-based
+	// constructor matching resolves it against exactly the type
+	// app.provideMessagePackages provides: dig/fx matches constructor
+	// parameters by exact reflect.Type and won't narrow chan T to <-chan T
+	// the way a direct Go call does.
+	in        chan MessagePackage
+	submitter Submitter
+
+	mu      sync.Mutex
+	pending []MessagePackage
+}
+
+func NewMessageBatcher(
+	config *Config,
+	in chan MessagePackage,
+	submitter Submitter,
+) *MessageBatcher {
+	return &MessageBatcher{
+		batchSize:        config.Ethereum.BatchSize,
+		batchTimeout:     config.Ethereum.BatchTimeout,
+		gasLimitPerBatch: config.Ethereum.GasLimitPerBatch,
+		gasPerPackage:    config.Ethereum.GasPerMessagePackage,
+		in:               in,
+		submitter:        submitter,
+	}
+}
+
+// DumpQueue returns the message packages currently queued in the batch
+// being built but not yet submitted, for the "dump-queue" debug command.
+func (b *MessageBatcher) DumpQueue() []MessagePackage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]MessagePackage(nil), b.pending...)
+}
+
+// setPending snapshots packages for DumpQueue to read concurrently with
+// Start's loop.
+func (b *MessageBatcher) setPending(packages []MessagePackage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pending = packages
+}
+
+// maxPackagesPerBatch returns how many packages can share one submitAll
+// call without the batch's estimated gas exceeding GasLimitPerBatch, and
+// never less than 1, even when one package's estimate alone exceeds it. This
+// is a conservative, config-driven estimate rather than a live eth_estimateGas
+// call, since the batch must be sized before it is submitted.
+func (b *MessageBatcher) maxPackagesPerBatch() int {
+	if b.gasPerPackage == 0 || b.gasLimitPerBatch == 0 {
+		return b.batchSize
+	}
+	max := int(b.gasLimitPerBatch / b.gasPerPackage)
+	if max < 1 {
+		max = 1
+	}
+	if max > b.batchSize {
+		return b.batchSize
+	}
+	return max
+}
+
+func (b *MessageBatcher) Start(ctx context.Context) error {
+	var commitment [32]byte
+	var packages []MessagePackage
+	maxPackages := b.maxPackagesPerBatch()
+	timer := time.NewTimer(b.batchTimeout)
+	defer timer.Stop()
+
+	flush := func() error {
+		if len(packages) == 0 {
+			return nil
+		}
+		log.WithFields(log.Fields{
+			"commitment": commitment,
+			"size":       len(packages),
+		}).Info("submitting batch of message packages")
+
+		err := b.submitter.SubmitAll(ctx, packages)
+		packages = nil
+		b.setPending(packages)
+
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = flush()
+			return ctx.Err()
+		case pkg := <-b.in:
+			// A package for a different commitment can't share a batch
+			// with the one being built: flush what we have first, then
+			// start the next batch with this package.
+			if len(packages) > 0 && pkg.commitmentHash != commitment {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+
+			if len(packages) == 0 {
+				commitment = pkg.commitmentHash
+				timer.Reset(b.batchTimeout)
+			}
+			packages = append(packages, pkg)
+			b.setPending(packages)
+
+			if len(packages) >= maxPackages {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		case <-timer.C:
+			if err := flush(); err != nil {
+				return err
+			}
+			timer.Reset(b.batchTimeout)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package parachain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSubmitter records every SubmitAll call it receives, optionally
+// failing on a configured call index, so tests can assert both what was
+// batched together and that a failure doesn't partially apply a batch.
+type fakeSubmitter struct {
+	calls   [][]MessagePackage
+	failOn  int
+	failErr error
+}
+
+func (s *fakeSubmitter) SubmitAll(ctx context.Context, packages []MessagePackage) error {
+	if s.failErr != nil && len(s.calls) == s.failOn {
+		s.calls = append(s.calls, packages)
+		return s.failErr
+	}
+	s.calls = append(s.calls, packages)
+	return nil
+}
+
+func testConfig() *Config {
+	return &Config{
+		Ethereum: EthereumConfig{
+			BatchSize:            10,
+			BatchTimeout:         50 * time.Millisecond,
+			GasLimitPerBatch:     0,
+			GasPerMessagePackage: 0,
+		},
+	}
+}
+
+func pkg(commitment byte) MessagePackage {
+	var hash [32]byte
+	hash[0] = commitment
+	return MessagePackage{commitmentHash: hash}
+}
+
+// A package for a different commitment must never be appended to the
+// batch it just caused to flush: it starts the next batch instead.
+func TestMessageBatcherGroupsByCommitment(t *testing.T) {
+	in := make(chan MessagePackage, 4)
+	submitter := &fakeSubmitter{}
+	batcher := NewMessageBatcher(testConfig(), in, submitter)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	in <- pkg(1)
+	in <- pkg(1)
+	in <- pkg(2)
+
+	done := make(chan error, 1)
+	go func() { done <- batcher.Start(ctx) }()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	<-done
+
+	if len(submitter.calls) != 2 {
+		t.Fatalf("expected 2 batches, got %d: %v", len(submitter.calls), submitter.calls)
+	}
+
+	first := submitter.calls[0]
+	if len(first) != 2 {
+		t.Fatalf("expected first batch to contain the 2 commitment-1 packages, got %d", len(first))
+	}
+	for _, p := range first {
+		if p.commitmentHash != pkg(1).commitmentHash {
+			t.Fatalf("first batch contains a package from a different commitment: %v", p.commitmentHash)
+		}
+	}
+
+	second := submitter.calls[1]
+	if len(second) != 1 || second[0].commitmentHash != pkg(2).commitmentHash {
+		t.Fatalf("expected second batch to contain only the commitment-2 package, got %v", second)
+	}
+}
+
+// Each batch is submitted with a single SubmitAll call, so a revert on
+// chain rolls back every message in the batch together rather than
+// leaving some applied and others not.
+func TestMessageBatcherPartialFailureRollsBackWholeBatch(t *testing.T) {
+	in := make(chan MessagePackage, 3)
+	submitter := &fakeSubmitter{failOn: 0, failErr: errors.New("submitAll reverted")}
+	batcher := NewMessageBatcher(testConfig(), in, submitter)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	in <- pkg(1)
+	in <- pkg(1)
+	in <- pkg(1)
+
+	err := batcher.Start(ctx)
+	if err == nil {
+		t.Fatal("expected Start to return the submitter's error")
+	}
+
+	if len(submitter.calls) != 1 {
+		t.Fatalf("expected exactly one SubmitAll call for the batch, got %d", len(submitter.calls))
+	}
+	if len(submitter.calls[0]) != 3 {
+		t.Fatalf("expected the single SubmitAll call to carry all 3 packages atomically, got %d", len(submitter.calls[0]))
+	}
+}
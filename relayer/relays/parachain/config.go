@@ -0,0 +1,47 @@
+package parachain
+
+import (
+	"time"
+
+	"github.com/snowfork/snowbridge/relayer/relays/debug"
+	"github.com/snowfork/snowbridge/relayer/reconnect"
+)
+
+// Config is the parachain relay's configuration, loaded from the relay's
+// config file.
+type Config struct {
+	Parachain ParachainConfig
+	Polkadot  PolkadotConfig
+	Ethereum  EthereumConfig
+	Debug     debug.Config
+	Reconnect reconnect.Config
+}
+
+type ParachainConfig struct {
+	Endpoint string
+}
+
+type PolkadotConfig struct {
+	Endpoint string
+}
+
+// EthereumConfig configures both the Ethereum connection and how the
+// MessageBatcher groups and sizes batches submitted to it.
+type EthereumConfig struct {
+	Endpoint string
+
+	// InboundChannelContract is the address of the inbound channel
+	// contract that EthereumChannelWriter submits batches to.
+	InboundChannelContract string
+
+	// BatchSize caps how many message packages share one batch.
+	BatchSize int
+	// BatchTimeout flushes a partial batch if it hasn't filled within
+	// this long of the first package arriving.
+	BatchTimeout time.Duration
+	// GasLimitPerBatch and GasPerMessagePackage bound BatchSize further,
+	// so no submitAll call of more than one package is estimated to exceed
+	// the gas limit.
+	GasLimitPerBatch     uint64
+	GasPerMessagePackage uint64
+}
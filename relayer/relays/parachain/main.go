@@ -10,6 +10,7 @@ import (
 	"github.com/snowfork/snowbridge/relayer/chain/parachain"
 	"github.com/snowfork/snowbridge/relayer/chain/relaychain"
 	"github.com/snowfork/snowbridge/relayer/crypto/secp256k1"
+	"github.com/snowfork/snowbridge/relayer/relays/debug"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -21,6 +22,11 @@ type Relay struct {
 	ethereumConn          *ethereum.Connection
 	ethereumChannelWriter *EthereumChannelWriter
 	beefyListener         *BeefyListener
+	batcher               *MessageBatcher
+
+	parachainReconn  *parachain.ReconnectingConnection
+	relaychainReconn *relaychain.ReconnectingConnection
+	ethereumReconn   *ethereum.ReconnectingConnection
 }
 
 func NewRelay(config *Config, keypair *secp256k1.Keypair) (*Relay, error) {
@@ -31,18 +37,22 @@ func NewRelay(config *Config, keypair *secp256k1.Keypair) (*Relay, error) {
 	relaychainConn := relaychain.NewConnection(config.Polkadot.Endpoint)
 	ethereumConn := ethereum.NewConnection(config.Ethereum.Endpoint, keypair)
 
-	// channel for messages from beefy listener to ethereum writer
+	// channel for messages from beefy listener to the batcher
 	var messagePackages = make(chan MessagePackage, 1)
 
 	ethereumChannelWriter, err := NewEthereumChannelWriter(
 		config,
 		ethereumConn,
-		messagePackages,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// The batcher groups packages sharing an MMR leaf / commitment and
+	// hands each batch to the writer's SubmitAll in a single transaction,
+	// instead of one submission per package.
+	batcher := NewMessageBatcher(config, messagePackages, ethereumChannelWriter)
+
 	beefyListener := NewBeefyListener(
 		config,
 		ethereumConn,
@@ -58,6 +68,11 @@ func NewRelay(config *Config, keypair *secp256k1.Keypair) (*Relay, error) {
 		ethereumConn:          ethereumConn,
 		ethereumChannelWriter: ethereumChannelWriter,
 		beefyListener:         beefyListener,
+		batcher:               batcher,
+
+		parachainReconn:  parachain.NewReconnectingConnection(parachainConn, config.Reconnect, nil),
+		relaychainReconn: relaychain.NewReconnectingConnection(relaychainConn, config.Reconnect, nil),
+		ethereumReconn:   ethereum.NewReconnectingConnection(ethereumConn, config.Reconnect, nil),
 	}, nil
 }
 
@@ -68,46 +83,128 @@ func (relay *Relay) Start(ctx context.Context, eg *errgroup.Group) error {
 		return fmt.Errorf("Sender and/or receiver need to be set before starting chain")
 	}
 
-	err := relay.parachainConn.Connect(ctx)
+	err := relay.parachainReconn.Connect(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = relay.ethereumConn.Connect(ctx)
+	err = relay.ethereumReconn.Connect(ctx)
 	if err != nil {
 		return err
 	}
 
-	err = relay.relaychainConn.Connect(ctx)
+	err = relay.relaychainReconn.Connect(ctx)
 	if err != nil {
 		return err
 	}
 
 	eg.Go(func() error {
-		if relay.ethereumChannelWriter != nil {
-			log.Info("Starting Writer")
-			err = relay.ethereumChannelWriter.Start(ctx, eg)
-			if err != nil {
-				return err
-			}
+		if relay.ethereumChannelWriter == nil {
+			return nil
 		}
-		return nil
+		return relay.ethereumReconn.RunWithReconnect(ctx, func(ctx context.Context) error {
+			<-relay.ethereumReconn.Ready()
+			log.Info("Starting Writer")
+			return relay.ethereumChannelWriter.Start(ctx, eg)
+		})
 	})
 
 	eg.Go(func() error {
-		if relay.beefyListener != nil {
-			log.Info("Starting Beefy Listener")
-			err = relay.beefyListener.Start(ctx, eg)
-			if err != nil {
-				return err
-			}
+		if relay.beefyListener == nil {
+			return nil
 		}
-		return nil
+		return relay.reconnectWorker(ctx, "beefy listener", func(ctx context.Context) error {
+			<-relay.parachainReconn.Ready()
+			<-relay.relaychainReconn.Ready()
+			<-relay.ethereumReconn.Ready()
+			log.Info("Starting Beefy Listener")
+			return relay.beefyListener.Start(ctx, eg)
+		})
+	})
+
+	eg.Go(func() error {
+		// SubmitAll (the Ethereum RPC call) now runs inside the batcher's
+		// flush, not inside the writer's Start, so the batcher needs the
+		// same Ready()-gating and RunWithReconnect wrapping the writer
+		// goroutine gets: otherwise a transient Ethereum RPC error here
+		// returns from Start, eg.Go treats it as fatal, and the whole
+		// relay (beefy listener, writer, debug server included) tears
+		// down on what should have been a recoverable disconnect.
+		return relay.ethereumReconn.RunWithReconnect(ctx, func(ctx context.Context) error {
+			<-relay.ethereumReconn.Ready()
+			log.Info("Starting message package batcher")
+			return relay.batcher.Start(ctx)
+		})
 	})
 
+	if relay.config.Debug.Enabled {
+		server := debug.NewServer(relay.config.Debug,
+			debug.Target{Name: "relay", Value: relay},
+			debug.Target{Name: "writer", Value: relay.ethereumChannelWriter},
+			debug.Target{Name: "listener", Value: relay.beefyListener},
+			debug.Target{Name: "batcher", Value: relay.batcher},
+		)
+		eg.Go(func() error {
+			return server.Start(ctx)
+		})
+	}
+
 	return nil
 }
 
+// reconnectWorker runs start in a loop, and if it returns an error treats
+// that as any of the worker's underlying connections having dropped:
+// redialing all three chain connections with backoff before retrying
+// start, rather than propagating the error and tearing down the relay.
+// Used by workers like the BeefyListener that read from more than one
+// chain connection, so a single Wrapper.RunWithReconnect isn't enough.
+func (relay *Relay) reconnectWorker(ctx context.Context, name string, start func(ctx context.Context) error) error {
+	for {
+		err := start(ctx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		log.WithError(err).Warnf("%s stopped unexpectedly, reconnecting", name)
+
+		if err := relay.parachainReconn.Reconnect(ctx); err != nil {
+			return err
+		}
+		if err := relay.relaychainReconn.Reconnect(ctx); err != nil {
+			return err
+		}
+		if err := relay.ethereumReconn.Reconnect(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// Status reports which sub-workers have been set up, for the "status"
+// debug command.
+func (relay *Relay) Status() map[string]bool {
+	return map[string]bool{
+		"writer":   relay.ethereumChannelWriter != nil,
+		"listener": relay.beefyListener != nil,
+	}
+}
+
+// Reconnect redials the named chain connection with exponential backoff,
+// for the "reconnect ethereum|parachain|relaychain" debug command.
+func (relay *Relay) Reconnect(chain string) error {
+	ctx := context.Background()
+
+	switch chain {
+	case "ethereum":
+		return relay.ethereumReconn.Reconnect(ctx)
+	case "parachain":
+		return relay.parachainReconn.Reconnect(ctx)
+	case "relaychain":
+		return relay.relaychainReconn.Reconnect(ctx)
+	default:
+		return fmt.Errorf("unknown chain %q", chain)
+	}
+}
+
 func (relay *Relay) Stop() {
 	if relay.parachainConn != nil {
 		relay.parachainConn.Close()
@@ -0,0 +1,11 @@
+package parachain
+
+// MessagePackage bundles a parachain header, the channel messages proven
+// against it, and the merkle proof tying them together, as produced by
+// BeefyListener for one MMR leaf / commitment.
+type MessagePackage struct {
+	commitmentHash [32]byte
+	Header         []byte
+	Messages       [][]byte
+	Proof          []byte
+}
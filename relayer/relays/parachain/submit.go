@@ -0,0 +1,12 @@
+package parachain
+
+import "context"
+
+// Submitter submits every MessagePackage in a batch to the inbound channel
+// contract in a single submitAll(headers[], messages[][], proofs[])
+// transaction, so a revert on-chain rolls back every message in the batch
+// together instead of leaving some applied and others not. EthereumChannelWriter
+// implements this.
+type Submitter interface {
+	SubmitAll(ctx context.Context, packages []MessagePackage) error
+}
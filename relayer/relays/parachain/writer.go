@@ -0,0 +1,99 @@
+package parachain
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/snowfork/snowbridge/relayer/chain/ethereum"
+)
+
+// EthereumChannelWriter submits verified message packages from the
+// parachain to the Ethereum inbound channel contract. It implements
+// Submitter so a MessageBatcher can hand it whole batches to submit in one
+// transaction.
+type EthereumChannelWriter struct {
+	config *Config
+	conn   *ethereum.Connection
+
+	mu      sync.RWMutex
+	running chan struct{}
+}
+
+func NewEthereumChannelWriter(
+	config *Config,
+	conn *ethereum.Connection,
+) (*EthereumChannelWriter, error) {
+	running := make(chan struct{})
+	close(running)
+
+	return &EthereumChannelWriter{
+		config:  config,
+		conn:    conn,
+		running: running,
+	}, nil
+}
+
+// Start is a no-op beyond satisfying the worker lifecycle: there is nothing
+// left to consume from a channel here, since batches are handed to
+// SubmitAll directly by the MessageBatcher.
+func (wr *EthereumChannelWriter) Start(ctx context.Context, eg *errgroup.Group) error {
+	return nil
+}
+
+// PauseWriter stops SubmitAll from submitting further batches until
+// ResumeWriter is called, for the "pause-writer" debug command. A batch
+// already mid-flight when paused still completes; only the next one waits.
+func (wr *EthereumChannelWriter) PauseWriter() {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	select {
+	case <-wr.running:
+		wr.running = make(chan struct{})
+	default:
+	}
+}
+
+// ResumeWriter resumes submitting batches paused by PauseWriter, for the
+// "resume-writer" debug command.
+func (wr *EthereumChannelWriter) ResumeWriter() {
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	select {
+	case <-wr.running:
+	default:
+		close(wr.running)
+	}
+}
+
+func (wr *EthereumChannelWriter) readyToSubmit() <-chan struct{} {
+	wr.mu.RLock()
+	defer wr.mu.RUnlock()
+	return wr.running
+}
+
+// SubmitAll submits every package in the batch as a single
+// submitAll(headers[], messages[][], proofs[]) transaction on the inbound
+// channel contract, so the contract reverts the whole batch together
+// rather than applying some packages and not others. It blocks while the
+// writer is paused via PauseWriter.
+func (wr *EthereumChannelWriter) SubmitAll(ctx context.Context, packages []MessagePackage) error {
+	select {
+	case <-wr.readyToSubmit():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	headers := make([][]byte, len(packages))
+	messages := make([][][]byte, len(packages))
+	proofs := make([][]byte, len(packages))
+
+	for i, pkg := range packages {
+		headers[i] = pkg.Header
+		messages[i] = pkg.Messages
+		proofs[i] = pkg.Proof
+	}
+
+	return wr.conn.SubmitAll(ctx, wr.config.Ethereum.InboundChannelContract, headers, messages, proofs)
+}